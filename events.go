@@ -0,0 +1,130 @@
+package registry
+
+// EventKind identifies the kind of mutation an Event describes.
+type EventKind int
+
+const (
+	// Registered is published when a new item is added under a key that
+	// was not previously in use.
+	Registered EventKind = iota
+	// Replaced is published when Set overwrites an existing item.
+	Replaced
+	// Deleted is published when an item is removed via Delete.
+	Deleted
+)
+
+// Event describes a single mutation of a Registry.
+type Event[T any] struct {
+	Kind EventKind
+	Key  string
+	Item T
+}
+
+// subscriber holds either a buffered channel or a synchronous callback, but
+// never both.
+type subscriber[T any] struct {
+	id       uint64
+	ch       chan Event[T]
+	fn       func(Event[T])
+	coalesce bool
+}
+
+// SubscribeOption configures a subscription created by Subscribe.
+type SubscribeOption[T any] func(*subscriber[T])
+
+// WithCoalesce makes a channel subscription coalesce on backpressure: when
+// its buffer is full, the oldest pending event is dropped to make room for
+// the newest one instead of dropping the newest event.
+func WithCoalesce[T any]() SubscribeOption[T] {
+	return func(s *subscriber[T]) {
+		s.coalesce = true
+	}
+}
+
+// Subscribe returns a channel of Events published by Register, Set, and
+// Delete, along with an unsubscribe function that closes the channel and
+// stops delivery. buf sets the channel's buffer size (minimum 1). Delivery
+// is non-blocking: by default a full buffer drops the new event; pass
+// WithCoalesce to instead drop the oldest buffered event and keep the
+// newest. Events are never delivered while a Registry lock is held.
+func (r *Registry[T]) Subscribe(buf int, opts ...SubscribeOption[T]) (<-chan Event[T], func()) {
+	if buf < 1 {
+		buf = 1
+	}
+
+	sub := &subscriber[T]{ch: make(chan Event[T], buf)}
+	for _, opt := range opts {
+		opt(sub)
+	}
+
+	r.addSubscriber(sub)
+	return sub.ch, func() { r.removeSubscriber(sub.id) }
+}
+
+// SubscribeFunc registers fn to be called synchronously for every Event
+// published by Register, Set, and Delete. fn is never called while a
+// Registry lock is held, so it may call back into the same Registry. It is
+// called on the goroutine performing the mutation, so a blocking fn blocks
+// that mutation. The returned function unsubscribes fn.
+func (r *Registry[T]) SubscribeFunc(fn func(Event[T])) func() {
+	sub := &subscriber[T]{fn: fn}
+	r.addSubscriber(sub)
+	return func() { r.removeSubscriber(sub.id) }
+}
+
+func (r *Registry[T]) addSubscriber(sub *subscriber[T]) {
+	r.subMu.Lock()
+	defer r.subMu.Unlock()
+
+	r.nextSubID++
+	sub.id = r.nextSubID
+	r.subscribers = append(r.subscribers, sub)
+}
+
+func (r *Registry[T]) removeSubscriber(id uint64) {
+	r.subMu.Lock()
+	defer r.subMu.Unlock()
+
+	for i, s := range r.subscribers {
+		if s.id != id {
+			continue
+		}
+		r.subscribers = append(r.subscribers[:i], r.subscribers[i+1:]...)
+		if s.ch != nil {
+			close(s.ch)
+		}
+		return
+	}
+}
+
+// emit delivers evt to every subscriber. It must never be called while r.mu
+// is held, since SubscribeFunc callbacks may call back into the Registry.
+func (r *Registry[T]) emit(evt Event[T]) {
+	r.subMu.Lock()
+	subscribers := make([]*subscriber[T], len(r.subscribers))
+	copy(subscribers, r.subscribers)
+	r.subMu.Unlock()
+
+	for _, sub := range subscribers {
+		if sub.fn != nil {
+			sub.fn(evt)
+			continue
+		}
+
+		select {
+		case sub.ch <- evt:
+		default:
+			if !sub.coalesce {
+				continue
+			}
+			select {
+			case <-sub.ch:
+			default:
+			}
+			select {
+			case sub.ch <- evt:
+			default:
+			}
+		}
+	}
+}
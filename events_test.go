@@ -0,0 +1,119 @@
+package registry
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRegistryEvents(t *testing.T) {
+	t.Run("Subscribe receives Registered and Deleted events", func(t *testing.T) {
+		registry := NewRegistry[int]()
+		ch, unsubscribe := registry.Subscribe(4)
+		defer unsubscribe()
+
+		if err := registry.Register("a", 1); err != nil {
+			t.Fatalf("Register failed: %v", err)
+		}
+		registry.Delete("a")
+
+		evt := recvEvent(t, ch)
+		if evt.Kind != Registered || evt.Key != "a" || evt.Item != 1 {
+			t.Errorf("unexpected first event: %+v", evt)
+		}
+
+		evt = recvEvent(t, ch)
+		if evt.Kind != Deleted || evt.Key != "a" {
+			t.Errorf("unexpected second event: %+v", evt)
+		}
+	})
+
+	t.Run("Set publishes Replaced for an existing key", func(t *testing.T) {
+		registry := NewRegistry[int]()
+		_ = registry.Register("a", 1)
+
+		ch, unsubscribe := registry.Subscribe(4)
+		defer unsubscribe()
+
+		if err := registry.Set("a", 2); err != nil {
+			t.Fatalf("Set failed: %v", err)
+		}
+
+		evt := recvEvent(t, ch)
+		if evt.Kind != Replaced || evt.Item != 2 {
+			t.Errorf("expected Replaced event with item 2, got %+v", evt)
+		}
+	})
+
+	t.Run("unsubscribe closes the channel and stops delivery", func(t *testing.T) {
+		registry := NewRegistry[int]()
+		ch, unsubscribe := registry.Subscribe(4)
+		unsubscribe()
+
+		if err := registry.Register("a", 1); err != nil {
+			t.Fatalf("Register failed: %v", err)
+		}
+
+		if _, open := <-ch; open {
+			t.Error("expected channel to be closed after unsubscribe")
+		}
+	})
+
+	t.Run("full buffer drops the new event without coalescing", func(t *testing.T) {
+		registry := NewRegistry[int]()
+		ch, unsubscribe := registry.Subscribe(1)
+		defer unsubscribe()
+
+		_ = registry.Register("a", 1)
+		_ = registry.Register("b", 2)
+
+		evt := recvEvent(t, ch)
+		if evt.Key != "a" {
+			t.Errorf("expected the first event to survive, got %+v", evt)
+		}
+		select {
+		case extra := <-ch:
+			t.Errorf("expected no further buffered event, got %+v", extra)
+		default:
+		}
+	})
+
+	t.Run("full buffer with WithCoalesce keeps the newest event", func(t *testing.T) {
+		registry := NewRegistry[int]()
+		ch, unsubscribe := registry.Subscribe(1, WithCoalesce[int]())
+		defer unsubscribe()
+
+		_ = registry.Register("a", 1)
+		_ = registry.Register("b", 2)
+
+		evt := recvEvent(t, ch)
+		if evt.Key != "b" {
+			t.Errorf("expected the newest event to survive, got %+v", evt)
+		}
+	})
+
+	t.Run("SubscribeFunc is called synchronously", func(t *testing.T) {
+		registry := NewRegistry[int]()
+		var got []Event[int]
+		unsubscribe := registry.SubscribeFunc(func(evt Event[int]) {
+			got = append(got, evt)
+		})
+		defer unsubscribe()
+
+		_ = registry.Register("a", 1)
+
+		if len(got) != 1 || got[0].Key != "a" {
+			t.Errorf("unexpected events delivered: %+v", got)
+		}
+	})
+}
+
+func recvEvent(t *testing.T, ch <-chan Event[int]) Event[int] {
+	t.Helper()
+	select {
+	case evt := <-ch:
+		return evt
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for event")
+		return Event[int]{}
+	}
+}
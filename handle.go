@@ -0,0 +1,140 @@
+package registry
+
+import "reflect"
+
+// Handle is an opaque reference to an item registered via RegisterHandle.
+// Unlike a string key, resolving a Handle is O(1) with no string hashing.
+type Handle uint64
+
+// handleEntry tracks the refcounted, possibly-already-deleted state behind
+// a Handle.
+type handleEntry[T any] struct {
+	key      string
+	item     T
+	refCount int
+	deleted  bool
+}
+
+// RegisterHandle registers item under key, exactly like Register, and
+// additionally returns a Handle for O(1) access via Resolve. If item is a
+// pointer that is already registered under another handle, the existing
+// handle is returned (re-pointed at key) instead of allocating a new one.
+func (r *Registry[T]) RegisterHandle(key string, item T) (Handle, error) {
+	if err := r.Register(key, item); err != nil {
+		return 0, err
+	}
+
+	r.handleMu.Lock()
+	defer r.handleMu.Unlock()
+
+	if addr, ok := pointerIdentity(item); ok {
+		if h, exists := r.identityHandles[addr]; exists {
+			if entry, ok := r.handles[h]; ok {
+				delete(r.handlesByKey, entry.key)
+				entry.key = key
+				entry.deleted = false
+				r.handlesByKey[key] = h
+				return h, nil
+			}
+			// The previous handle for this pointer was already purged
+			// (Delete+Release with no outstanding Acquire); reuse its id
+			// so the identity-to-handle mapping still holds.
+			r.handles[h] = &handleEntry[T]{key: key, item: item}
+			r.handlesByKey[key] = h
+			return h, nil
+		}
+	}
+
+	r.nextHandle++
+	h := Handle(r.nextHandle)
+	r.handles[h] = &handleEntry[T]{key: key, item: item}
+	r.handlesByKey[key] = h
+	if addr, ok := pointerIdentity(item); ok {
+		r.identityHandles[addr] = h
+	}
+
+	return h, nil
+}
+
+// Resolve retrieves the item behind a Handle in O(1), regardless of whether
+// the item's key has since been deleted (as long as its refcount hasn't
+// reached zero; see Acquire/Release).
+func (r *Registry[T]) Resolve(h Handle) (T, bool) {
+	r.handleMu.Lock()
+	defer r.handleMu.Unlock()
+
+	entry, exists := r.handles[h]
+	if !exists {
+		var zero T
+		return zero, false
+	}
+	return entry.item, true
+}
+
+// Acquire increments h's reference count, keeping its item resolvable via
+// Resolve even after Delete removes it from the registry, until a matching
+// Release brings the count back to zero.
+func (r *Registry[T]) Acquire(h Handle) {
+	r.handleMu.Lock()
+	defer r.handleMu.Unlock()
+
+	if entry, exists := r.handles[h]; exists {
+		entry.refCount++
+	}
+}
+
+// Release decrements h's reference count. If the item's key has already
+// been removed via Delete and the count reaches zero, the handle is purged
+// and Resolve will subsequently report not found.
+func (r *Registry[T]) Release(h Handle) {
+	r.handleMu.Lock()
+	defer r.handleMu.Unlock()
+
+	entry, exists := r.handles[h]
+	if !exists {
+		return
+	}
+	if entry.refCount > 0 {
+		entry.refCount--
+	}
+	if entry.deleted && entry.refCount == 0 {
+		r.purgeHandleLocked(h, entry)
+	}
+}
+
+// markHandleDeleted flags the handle behind key (if any) as deleted, and
+// purges it immediately if nothing currently holds a reference to it.
+func (r *Registry[T]) markHandleDeleted(key string) {
+	r.handleMu.Lock()
+	defer r.handleMu.Unlock()
+
+	h, exists := r.handlesByKey[key]
+	if !exists {
+		return
+	}
+
+	entry := r.handles[h]
+	entry.deleted = true
+	if entry.refCount == 0 {
+		r.purgeHandleLocked(h, entry)
+	}
+}
+
+// purgeHandleLocked removes a deleted, unreferenced handle. The
+// identityHandles mapping for a pointer item is deliberately left in
+// place so that re-registering the same pointer later still resolves to
+// the same Handle, per RegisterHandle's identity-reuse guarantee.
+func (r *Registry[T]) purgeHandleLocked(h Handle, entry *handleEntry[T]) {
+	delete(r.handles, h)
+	delete(r.handlesByKey, entry.key)
+}
+
+// pointerIdentity returns the pointer address of item and true if item is a
+// non-nil pointer, or (0, false) otherwise.
+func pointerIdentity[T any](item T) (uintptr, bool) {
+	v := reflect.ValueOf(item)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return 0, false
+	}
+	return v.Pointer(), true
+}
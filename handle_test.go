@@ -0,0 +1,103 @@
+package registry
+
+import "testing"
+
+func TestRegistryHandles(t *testing.T) {
+	t.Run("RegisterHandle and Resolve", func(t *testing.T) {
+		registry := NewRegistry[string]()
+
+		h, err := registry.RegisterHandle("a", "alpha")
+		if err != nil {
+			t.Fatalf("RegisterHandle failed: %v", err)
+		}
+
+		got, ok := registry.Resolve(h)
+		if !ok || got != "alpha" {
+			t.Errorf("Resolve = (%q, %v), want (\"alpha\", true)", got, ok)
+		}
+	})
+
+	t.Run("RegisterHandle rejects duplicate keys like Register", func(t *testing.T) {
+		registry := NewRegistry[string]()
+		if _, err := registry.RegisterHandle("a", "alpha"); err != nil {
+			t.Fatalf("RegisterHandle failed: %v", err)
+		}
+		if _, err := registry.RegisterHandle("a", "other"); err == nil {
+			t.Error("expected error registering a duplicate key")
+		}
+	})
+
+	t.Run("re-registering the same pointer returns the same handle", func(t *testing.T) {
+		registry := NewRegistry[*int]()
+		value := 42
+
+		h1, err := registry.RegisterHandle("a", &value)
+		if err != nil {
+			t.Fatalf("RegisterHandle failed: %v", err)
+		}
+		registry.Delete("a")
+
+		h2, err := registry.RegisterHandle("b", &value)
+		if err != nil {
+			t.Fatalf("RegisterHandle failed: %v", err)
+		}
+
+		if h1 != h2 {
+			t.Errorf("expected same handle for the same pointer, got %v and %v", h1, h2)
+		}
+	})
+
+	t.Run("item stays resolvable after Delete until refcount reaches zero", func(t *testing.T) {
+		registry := NewRegistry[string]()
+		h, _ := registry.RegisterHandle("a", "alpha")
+		registry.Acquire(h)
+
+		registry.Delete("a")
+		if _, exists := registry.Get("a"); exists {
+			t.Error("expected Get to report the key as gone")
+		}
+
+		got, ok := registry.Resolve(h)
+		if !ok || got != "alpha" {
+			t.Error("expected Resolve to still find the item while a reference is held")
+		}
+
+		registry.Release(h)
+		if _, ok := registry.Resolve(h); ok {
+			t.Error("expected Resolve to fail once the last reference is released after Delete")
+		}
+	})
+
+	t.Run("deleting a handle's old key after it moved to a new key doesn't purge the new key", func(t *testing.T) {
+		registry := NewRegistry[*int]()
+		value := 42
+
+		h1, err := registry.RegisterHandle("a", &value)
+		if err != nil {
+			t.Fatalf("RegisterHandle failed: %v", err)
+		}
+		h2, err := registry.RegisterHandle("b", &value)
+		if err != nil {
+			t.Fatalf("RegisterHandle failed: %v", err)
+		}
+		if h1 != h2 {
+			t.Fatalf("expected same handle for the same pointer, got %v and %v", h1, h2)
+		}
+
+		registry.Delete("a")
+
+		if _, ok := registry.Get("b"); !ok {
+			t.Error("expected key 'b' to remain registered after deleting the unrelated key 'a'")
+		}
+		if got, ok := registry.Resolve(h2); !ok || *got != value {
+			t.Errorf("expected handle still resolvable after deleting 'a', got (%v, %v)", got, ok)
+		}
+	})
+
+	t.Run("Resolve fails for an unknown handle", func(t *testing.T) {
+		registry := NewRegistry[string]()
+		if _, ok := registry.Resolve(Handle(999)); ok {
+			t.Error("expected Resolve to fail for an unregistered handle")
+		}
+	})
+}
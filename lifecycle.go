@@ -0,0 +1,87 @@
+package registry
+
+import (
+	"context"
+	"net/http"
+)
+
+// Initializer is an optional capability interface for items managed by a
+// TypeRegistry. Items implementing it have Init called when registered via
+// RegisterWithTypeAndInit, or in bulk via InitAll.
+type Initializer interface {
+	Init(ctx context.Context) error
+}
+
+// Closer is an optional capability interface for items managed by a
+// TypeRegistry. Items implementing it have Close called by CloseAll.
+type Closer interface {
+	Close() error
+}
+
+// HTTPCapable is an optional capability interface for items that need an
+// *http.Client injected after construction, e.g. by InjectHTTPClient.
+type HTTPCapable interface {
+	SetHTTPClient(*http.Client)
+}
+
+// RegisterWithTypeAndInit behaves like RegisterWithType, additionally
+// calling Init on the item if it implements Initializer. If Init returns an
+// error, the registration is rolled back and the error is returned.
+func (r *TypeRegistry[T, K]) RegisterWithTypeAndInit(ctx context.Context, itemType K, name string, item T) error {
+	if err := r.RegisterWithType(itemType, name, item); err != nil {
+		return err
+	}
+
+	if initer, ok := any(item).(Initializer); ok {
+		if err := initer.Init(ctx); err != nil {
+			r.DeleteByType(itemType, name)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// InitAll calls Init on every registered item that implements Initializer.
+// It returns the first error encountered, if any; items are initialized in
+// no particular order.
+func (r *TypeRegistry[T, K]) InitAll(ctx context.Context) error {
+	var firstErr error
+	for _, item := range r.registry.Items() {
+		initer, ok := any(item).(Initializer)
+		if !ok {
+			continue
+		}
+		if err := initer.Init(ctx); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// CloseAll calls Close on every registered item that implements Closer. It
+// always attempts to close every item, returning the first error
+// encountered, if any.
+func (r *TypeRegistry[T, K]) CloseAll() error {
+	var firstErr error
+	for _, item := range r.registry.Items() {
+		closer, ok := any(item).(Closer)
+		if !ok {
+			continue
+		}
+		if err := closer.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// InjectHTTPClient calls SetHTTPClient on every registered item that
+// implements HTTPCapable.
+func (r *TypeRegistry[T, K]) InjectHTTPClient(client *http.Client) {
+	for _, item := range r.registry.Items() {
+		if capable, ok := any(item).(HTTPCapable); ok {
+			capable.SetHTTPClient(client)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package registry
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+type lifecyclePlugin struct {
+	name       string
+	initErr    error
+	closeErr   error
+	inited     bool
+	closed     bool
+	httpClient *http.Client
+}
+
+func (p *lifecyclePlugin) Init(ctx context.Context) error {
+	p.inited = true
+	return p.initErr
+}
+
+func (p *lifecyclePlugin) Close() error {
+	p.closed = true
+	return p.closeErr
+}
+
+func (p *lifecyclePlugin) SetHTTPClient(c *http.Client) {
+	p.httpClient = c
+}
+
+func TestTypeRegistryLifecycle(t *testing.T) {
+	t.Run("RegisterWithTypeAndInit succeeds", func(t *testing.T) {
+		registry := NewTypeRegistry[*lifecyclePlugin, ComponentType](Tool)
+		plugin := &lifecyclePlugin{name: "a"}
+
+		if err := registry.RegisterWithTypeAndInit(context.Background(), Tool, "a", plugin); err != nil {
+			t.Fatalf("RegisterWithTypeAndInit failed: %v", err)
+		}
+		if !plugin.inited {
+			t.Error("expected Init to be called")
+		}
+		if _, exists := registry.GetByType(Tool, "a"); !exists {
+			t.Error("expected plugin to remain registered")
+		}
+	})
+
+	t.Run("RegisterWithTypeAndInit rolls back on Init failure", func(t *testing.T) {
+		registry := NewTypeRegistry[*lifecyclePlugin, ComponentType](Tool)
+		plugin := &lifecyclePlugin{name: "a", initErr: errors.New("boom")}
+
+		err := registry.RegisterWithTypeAndInit(context.Background(), Tool, "a", plugin)
+		if err == nil {
+			t.Fatal("expected error from failing Init")
+		}
+		if _, exists := registry.GetByType(Tool, "a"); exists {
+			t.Error("expected registration to be rolled back")
+		}
+	})
+
+	t.Run("InitAll and CloseAll visit every capable item", func(t *testing.T) {
+		registry := NewTypeRegistry[*lifecyclePlugin, ComponentType](Tool, Stage)
+		a := &lifecyclePlugin{name: "a"}
+		b := &lifecyclePlugin{name: "b"}
+		_ = registry.RegisterWithType(Tool, "a", a)
+		_ = registry.RegisterWithType(Stage, "b", b)
+
+		if err := registry.InitAll(context.Background()); err != nil {
+			t.Fatalf("InitAll failed: %v", err)
+		}
+		if !a.inited || !b.inited {
+			t.Error("expected both plugins to be initialized")
+		}
+
+		if err := registry.CloseAll(); err != nil {
+			t.Fatalf("CloseAll failed: %v", err)
+		}
+		if !a.closed || !b.closed {
+			t.Error("expected both plugins to be closed")
+		}
+	})
+
+	t.Run("InjectHTTPClient sets the client on capable items", func(t *testing.T) {
+		registry := NewTypeRegistry[*lifecyclePlugin, ComponentType](Tool)
+		plugin := &lifecyclePlugin{name: "a"}
+		_ = registry.RegisterWithType(Tool, "a", plugin)
+
+		client := &http.Client{}
+		registry.InjectHTTPClient(client)
+
+		if plugin.httpClient != client {
+			t.Error("expected HTTP client to be injected")
+		}
+	})
+}
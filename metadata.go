@@ -0,0 +1,167 @@
+package registry
+
+import "fmt"
+
+// Metadata describes discoverable attributes attached to an item registered
+// via RegisterWithMeta.
+type Metadata struct {
+	Tags     []string
+	Labels   map[string]string
+	Priority int
+}
+
+// Entry pairs a registered item with its key and metadata, as returned by
+// FindByTag, FindByLabel, and Query.
+type Entry[T any] struct {
+	Key  string
+	Item T
+	Meta Metadata
+}
+
+// RegisterWithMeta adds an item with the given key and metadata, indexing
+// its tags and labels for FindByTag, FindByLabel, and Query. Returns an
+// error under the same conditions as Register.
+func (r *Registry[T]) RegisterWithMeta(key string, item T, meta Metadata) error {
+	r.mu.Lock()
+
+	if _, exists := r.items[key]; exists {
+		r.mu.Unlock()
+		return fmt.Errorf("item with key '%s' already exists", key)
+	}
+	if _, exists := r.subs[key]; exists {
+		r.mu.Unlock()
+		return fmt.Errorf("registry: %q is already registered as a sub-registry", key)
+	}
+
+	r.items[key] = item
+	r.meta[key] = meta
+	r.indexMeta(key, meta)
+	r.mu.Unlock()
+
+	r.emit(Event[T]{Kind: Registered, Key: key, Item: item})
+	return nil
+}
+
+// FindByTag returns every entry whose metadata includes tag.
+func (r *Registry[T]) FindByTag(tag string) []Entry[T] {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	keys := make([]string, 0, len(r.tagIndex[tag]))
+	for k := range r.tagIndex[tag] {
+		keys = append(keys, k)
+	}
+	return r.entriesForLocked(keys)
+}
+
+// FindByLabel returns every entry whose metadata has label k set to v.
+func (r *Registry[T]) FindByLabel(k, v string) []Entry[T] {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	set := r.labelIndex[labelIndexKey(k, v)]
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	return r.entriesForLocked(keys)
+}
+
+// Query returns every entry whose metadata satisfies pred.
+func (r *Registry[T]) Query(pred func(Metadata) bool) []Entry[T] {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	entries := make([]Entry[T], 0)
+	for key, meta := range r.meta {
+		if pred(meta) {
+			entries = append(entries, Entry[T]{Key: key, Item: r.items[key], Meta: meta})
+		}
+	}
+	return entries
+}
+
+// entriesForLocked builds Entry values for keys. Callers must hold r.mu.
+func (r *Registry[T]) entriesForLocked(keys []string) []Entry[T] {
+	entries := make([]Entry[T], 0, len(keys))
+	for _, k := range keys {
+		entries = append(entries, Entry[T]{Key: k, Item: r.items[k], Meta: r.meta[k]})
+	}
+	return entries
+}
+
+// indexMeta adds key to the tag and label indexes for meta. Callers must
+// hold r.mu.
+func (r *Registry[T]) indexMeta(key string, meta Metadata) {
+	for _, tag := range meta.Tags {
+		if r.tagIndex[tag] == nil {
+			r.tagIndex[tag] = make(map[string]struct{})
+		}
+		r.tagIndex[tag][key] = struct{}{}
+	}
+	for k, v := range meta.Labels {
+		lk := labelIndexKey(k, v)
+		if r.labelIndex[lk] == nil {
+			r.labelIndex[lk] = make(map[string]struct{})
+		}
+		r.labelIndex[lk][key] = struct{}{}
+	}
+}
+
+// unindexMeta removes key from the tag and label indexes built from its
+// previously registered metadata. Callers must hold r.mu.
+func (r *Registry[T]) unindexMeta(key string) {
+	meta, exists := r.meta[key]
+	if !exists {
+		return
+	}
+
+	for _, tag := range meta.Tags {
+		if set := r.tagIndex[tag]; set != nil {
+			delete(set, key)
+			if len(set) == 0 {
+				delete(r.tagIndex, tag)
+			}
+		}
+	}
+	for k, v := range meta.Labels {
+		lk := labelIndexKey(k, v)
+		if set := r.labelIndex[lk]; set != nil {
+			delete(set, key)
+			if len(set) == 0 {
+				delete(r.labelIndex, lk)
+			}
+		}
+	}
+}
+
+func labelIndexKey(k, v string) string {
+	return k + "\x00" + v
+}
+
+// RegisterWithTypeAndMeta adds an item with the given type, name, and
+// metadata. Returns an error under the same conditions as RegisterWithType.
+func (r *TypeRegistry[T, K]) RegisterWithTypeAndMeta(itemType K, name string, item T, meta Metadata) error {
+	if _, exists := r.types[itemType]; !exists {
+		return fmt.Errorf("invalid type: %v", itemType)
+	}
+
+	key := fmt.Sprintf("%v:%s", itemType, name)
+	return r.registry.RegisterWithMeta(key, item, meta)
+}
+
+// ItemsByTypeAndTag returns every entry of the given type whose metadata
+// includes tag, with keys stripped of their type prefix (as with
+// ItemsByType).
+func (r *TypeRegistry[T, K]) ItemsByTypeAndTag(itemType K, tag string) []Entry[T] {
+	prefix := fmt.Sprintf("%v:", itemType)
+
+	matches := r.registry.FindByTag(tag)
+	entries := make([]Entry[T], 0, len(matches))
+	for _, e := range matches {
+		if len(e.Key) > len(prefix) && e.Key[:len(prefix)] == prefix {
+			entries = append(entries, Entry[T]{Key: e.Key[len(prefix):], Item: e.Item, Meta: e.Meta})
+		}
+	}
+	return entries
+}
@@ -0,0 +1,96 @@
+package registry
+
+import "testing"
+
+func TestRegistryMetadata(t *testing.T) {
+	t.Run("FindByTag and FindByLabel", func(t *testing.T) {
+		registry := NewRegistry[string]()
+
+		_ = registry.RegisterWithMeta("a", "alpha", Metadata{
+			Tags:     []string{"fast", "stable"},
+			Labels:   map[string]string{"team": "core"},
+			Priority: 1,
+		})
+		_ = registry.RegisterWithMeta("b", "beta", Metadata{
+			Tags:   []string{"fast"},
+			Labels: map[string]string{"team": "edge"},
+		})
+
+		fast := registry.FindByTag("fast")
+		if len(fast) != 2 {
+			t.Fatalf("expected 2 entries tagged 'fast', got %d", len(fast))
+		}
+
+		stable := registry.FindByTag("stable")
+		if len(stable) != 1 || stable[0].Key != "a" {
+			t.Errorf("expected only 'a' tagged 'stable', got %+v", stable)
+		}
+
+		core := registry.FindByLabel("team", "core")
+		if len(core) != 1 || core[0].Key != "a" {
+			t.Errorf("expected only 'a' labeled team=core, got %+v", core)
+		}
+	})
+
+	t.Run("Query filters by predicate", func(t *testing.T) {
+		registry := NewRegistry[string]()
+		_ = registry.RegisterWithMeta("a", "alpha", Metadata{Priority: 5})
+		_ = registry.RegisterWithMeta("b", "beta", Metadata{Priority: 1})
+
+		high := registry.Query(func(m Metadata) bool { return m.Priority >= 5 })
+		if len(high) != 1 || high[0].Key != "a" {
+			t.Errorf("expected only 'a' to match, got %+v", high)
+		}
+	})
+
+	t.Run("Delete removes tag and label index entries", func(t *testing.T) {
+		registry := NewRegistry[string]()
+		_ = registry.RegisterWithMeta("a", "alpha", Metadata{
+			Tags:   []string{"fast"},
+			Labels: map[string]string{"team": "core"},
+		})
+
+		registry.Delete("a")
+
+		if got := registry.FindByTag("fast"); len(got) != 0 {
+			t.Errorf("expected no entries tagged 'fast' after delete, got %+v", got)
+		}
+		if got := registry.FindByLabel("team", "core"); len(got) != 0 {
+			t.Errorf("expected no entries labeled team=core after delete, got %+v", got)
+		}
+	})
+
+	t.Run("Set drops stale metadata from a previous RegisterWithMeta", func(t *testing.T) {
+		registry := NewRegistry[string]()
+		_ = registry.RegisterWithMeta("a", "alpha", Metadata{
+			Tags:   []string{"fast"},
+			Labels: map[string]string{"team": "core"},
+		})
+
+		if err := registry.Set("a", "zeta"); err != nil {
+			t.Fatalf("Set failed: %v", err)
+		}
+
+		if got := registry.FindByTag("fast"); len(got) != 0 {
+			t.Errorf("expected no entries tagged 'fast' after Set, got %+v", got)
+		}
+		if got := registry.FindByLabel("team", "core"); len(got) != 0 {
+			t.Errorf("expected no entries labeled team=core after Set, got %+v", got)
+		}
+	})
+}
+
+func TestTypeRegistryMetadata(t *testing.T) {
+	t.Run("ItemsByTypeAndTag intersects type and tag", func(t *testing.T) {
+		registry := NewTypeRegistry[string, ComponentType](Tool, Stage)
+
+		_ = registry.RegisterWithTypeAndMeta(Tool, "parser", "parser-impl", Metadata{Tags: []string{"fast"}})
+		_ = registry.RegisterWithTypeAndMeta(Stage, "parser", "stage-impl", Metadata{Tags: []string{"fast"}})
+		_ = registry.RegisterWithTypeAndMeta(Tool, "formatter", "formatter-impl", Metadata{Tags: []string{"slow"}})
+
+		tools := registry.ItemsByTypeAndTag(Tool, "fast")
+		if len(tools) != 1 || tools[0].Key != "parser" {
+			t.Errorf("expected only Tool 'parser' tagged 'fast', got %+v", tools)
+		}
+	})
+}
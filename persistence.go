@@ -0,0 +1,252 @@
+package registry
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Codec encodes and decodes items of type T for persistence. Encode and
+// Decode must round-trip: Decode(Encode(item)) == item.
+type Codec[T any] interface {
+	Encode(T) ([]byte, error)
+	Decode([]byte) (T, error)
+}
+
+// JSONCodec is a Codec that encodes items as JSON.
+type JSONCodec[T any] struct{}
+
+// Encode marshals item as JSON.
+func (JSONCodec[T]) Encode(item T) ([]byte, error) { return json.Marshal(item) }
+
+// Decode unmarshals data as JSON.
+func (JSONCodec[T]) Decode(data []byte) (T, error) {
+	var item T
+	err := json.Unmarshal(data, &item)
+	return item, err
+}
+
+// GobCodec is a Codec that encodes items using encoding/gob.
+type GobCodec[T any] struct{}
+
+// Encode gob-encodes item.
+func (GobCodec[T]) Encode(item T) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(item); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Decode gob-decodes data.
+func (GobCodec[T]) Decode(data []byte) (T, error) {
+	var item T
+	err := gob.NewDecoder(bytes.NewReader(data)).Decode(&item)
+	return item, err
+}
+
+// snapshotEntry is the on-disk representation of a single Registry entry.
+type snapshotEntry struct {
+	Key  string `json:"key"`
+	Data []byte `json:"data"`
+}
+
+// Snapshot writes every item in the registry to w. The envelope is JSON;
+// only each item's payload is encoded with codec, so a snapshot written
+// with one codec must be restored with a compatible one.
+func (r *Registry[T]) Snapshot(w io.Writer, codec Codec[T]) error {
+	items := r.Items()
+
+	entries := make([]snapshotEntry, 0, len(items))
+	for key, item := range items {
+		data, err := codec.Encode(item)
+		if err != nil {
+			return fmt.Errorf("registry: encode %q: %w", key, err)
+		}
+		entries = append(entries, snapshotEntry{Key: key, Data: data})
+	}
+
+	return json.NewEncoder(w).Encode(entries)
+}
+
+// Restore reads a snapshot produced by Snapshot from r and registers every
+// entry via Set, overwriting any existing items under the same keys.
+func (r *Registry[T]) Restore(reader io.Reader, codec Codec[T]) error {
+	var entries []snapshotEntry
+	if err := json.NewDecoder(reader).Decode(&entries); err != nil {
+		return fmt.Errorf("registry: decode snapshot: %w", err)
+	}
+
+	for _, entry := range entries {
+		item, err := codec.Decode(entry.Data)
+		if err != nil {
+			return fmt.Errorf("registry: decode %q: %w", entry.Key, err)
+		}
+		if err := r.Set(entry.Key, item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SnapshotAtomic writes a snapshot to path by writing to a temporary file in
+// the same directory and renaming it into place, so readers never observe a
+// partially written file.
+func (r *Registry[T]) SnapshotAtomic(path string, codec Codec[T]) error {
+	return snapshotAtomic(path, func(w io.Writer) error { return r.Snapshot(w, codec) })
+}
+
+// WatchFile polls path at interval and calls Restore with codec whenever its
+// modification time advances, until the returned stop function is called.
+// Errors seen while polling or restoring are ignored; use Restore directly
+// if you need to observe them.
+func (r *Registry[T]) WatchFile(path string, codec Codec[T], interval time.Duration) func() {
+	return watchFile(path, interval, func(reader io.Reader) error { return r.Restore(reader, codec) })
+}
+
+// typeSnapshotEntry is the on-disk representation of a single TypeRegistry
+// entry, tagging each item with its K type so Restore can validate it.
+type typeSnapshotEntry struct {
+	Type string `json:"type"`
+	Name string `json:"name"`
+	Data []byte `json:"data"`
+}
+
+// Snapshot writes every item in the registry to w, tagging each entry with
+// its type so Restore can validate it against the registry's valid types.
+func (r *TypeRegistry[T, K]) Snapshot(w io.Writer, codec Codec[T]) error {
+	items := r.registry.Items()
+
+	entries := make([]typeSnapshotEntry, 0, len(items))
+	for key, item := range items {
+		typeTag, name := splitTypeKey(key)
+		data, err := codec.Encode(item)
+		if err != nil {
+			return fmt.Errorf("registry: encode %q: %w", key, err)
+		}
+		entries = append(entries, typeSnapshotEntry{Type: typeTag, Name: name, Data: data})
+	}
+
+	return json.NewEncoder(w).Encode(entries)
+}
+
+// Restore reads a snapshot produced by Snapshot from reader and registers
+// every entry, overwriting any existing items under the same type and name.
+// It rejects the whole restore if any entry's type tag is not one of the
+// registry's valid types: every entry's type tag is validated and decoded
+// before any of them are written, so a failed Restore leaves the registry
+// untouched.
+func (r *TypeRegistry[T, K]) Restore(reader io.Reader, codec Codec[T]) error {
+	var entries []typeSnapshotEntry
+	if err := json.NewDecoder(reader).Decode(&entries); err != nil {
+		return fmt.Errorf("registry: decode snapshot: %w", err)
+	}
+
+	validTags := make(map[string]struct{}, len(r.types))
+	for t := range r.types {
+		validTags[fmt.Sprintf("%v", t)] = struct{}{}
+	}
+
+	items := make([]setManyEntry[T], 0, len(entries))
+	for _, entry := range entries {
+		if _, ok := validTags[entry.Type]; !ok {
+			return fmt.Errorf("registry: snapshot references unknown type %q", entry.Type)
+		}
+		item, err := codec.Decode(entry.Data)
+		if err != nil {
+			return fmt.Errorf("registry: decode %q:%q: %w", entry.Type, entry.Name, err)
+		}
+		items = append(items, setManyEntry[T]{key: fmt.Sprintf("%s:%s", entry.Type, entry.Name), item: item})
+	}
+
+	return r.registry.setMany(items)
+}
+
+// SnapshotAtomic writes a snapshot to path by writing to a temporary file in
+// the same directory and renaming it into place.
+func (r *TypeRegistry[T, K]) SnapshotAtomic(path string, codec Codec[T]) error {
+	return snapshotAtomic(path, func(w io.Writer) error { return r.Snapshot(w, codec) })
+}
+
+// WatchFile polls path at interval and calls Restore with codec whenever its
+// modification time advances, until the returned stop function is called.
+func (r *TypeRegistry[T, K]) WatchFile(path string, codec Codec[T], interval time.Duration) func() {
+	return watchFile(path, interval, func(reader io.Reader) error { return r.Restore(reader, codec) })
+}
+
+// splitTypeKey splits a TypeRegistry key of the form "type:name" (as built
+// by RegisterWithType) into its type tag and name.
+func splitTypeKey(key string) (typeTag, name string) {
+	idx := strings.IndexByte(key, ':')
+	if idx < 0 {
+		return key, ""
+	}
+	return key[:idx], key[idx+1:]
+}
+
+// snapshotAtomic writes whatever write produces to path via a temp file and
+// rename, shared by Registry.SnapshotAtomic and TypeRegistry.SnapshotAtomic.
+func snapshotAtomic(path string, write func(io.Writer) error) error {
+	dir := filepath.Dir(path)
+	tmp, err := os.CreateTemp(dir, filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+
+	if err := write(tmp); err != nil {
+		tmp.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+
+	return os.Rename(tmpPath, path)
+}
+
+// watchFile polls path at interval and calls restore whenever its
+// modification time advances, until the returned stop function is called.
+// Shared by Registry.WatchFile and TypeRegistry.WatchFile.
+func watchFile(path string, interval time.Duration, restore func(io.Reader) error) func() {
+	if interval <= 0 {
+		interval = time.Second
+	}
+
+	done := make(chan struct{})
+	go func() {
+		var lastMod time.Time
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				info, err := os.Stat(path)
+				if err != nil || !info.ModTime().After(lastMod) {
+					continue
+				}
+				lastMod = info.ModTime()
+
+				file, err := os.Open(path)
+				if err != nil {
+					continue
+				}
+				_ = restore(file)
+				file.Close()
+			}
+		}
+	}()
+
+	return func() { close(done) }
+}
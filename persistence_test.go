@@ -0,0 +1,210 @@
+package registry
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestRegistrySnapshotRestore(t *testing.T) {
+	t.Run("JSON codec round-trips via Snapshot/Restore", func(t *testing.T) {
+		src := NewRegistry[string]()
+		_ = src.Register("a", "alpha")
+		_ = src.Register("b", "beta")
+
+		var buf bytes.Buffer
+		if err := src.Snapshot(&buf, JSONCodec[string]{}); err != nil {
+			t.Fatalf("Snapshot failed: %v", err)
+		}
+
+		dst := NewRegistry[string]()
+		if err := dst.Restore(&buf, JSONCodec[string]{}); err != nil {
+			t.Fatalf("Restore failed: %v", err)
+		}
+
+		if got, _ := dst.Get("a"); got != "alpha" {
+			t.Errorf("expected %q, got %q", "alpha", got)
+		}
+		if got, _ := dst.Get("b"); got != "beta" {
+			t.Errorf("expected %q, got %q", "beta", got)
+		}
+	})
+
+	t.Run("gob codec round-trips via Snapshot/Restore", func(t *testing.T) {
+		src := NewRegistry[int]()
+		_ = src.Register("n", 42)
+
+		var buf bytes.Buffer
+		if err := src.Snapshot(&buf, GobCodec[int]{}); err != nil {
+			t.Fatalf("Snapshot failed: %v", err)
+		}
+
+		dst := NewRegistry[int]()
+		if err := dst.Restore(&buf, GobCodec[int]{}); err != nil {
+			t.Fatalf("Restore failed: %v", err)
+		}
+		if got, _ := dst.Get("n"); got != 42 {
+			t.Errorf("expected 42, got %d", got)
+		}
+	})
+
+	t.Run("SnapshotAtomic writes a complete file", func(t *testing.T) {
+		src := NewRegistry[string]()
+		_ = src.Register("a", "alpha")
+
+		path := filepath.Join(t.TempDir(), "snapshot.json")
+		if err := src.SnapshotAtomic(path, JSONCodec[string]{}); err != nil {
+			t.Fatalf("SnapshotAtomic failed: %v", err)
+		}
+
+		file, err := os.Open(path)
+		if err != nil {
+			t.Fatalf("failed to open snapshot file: %v", err)
+		}
+		defer file.Close()
+
+		dst := NewRegistry[string]()
+		if err := dst.Restore(file, JSONCodec[string]{}); err != nil {
+			t.Fatalf("Restore failed: %v", err)
+		}
+		if got, _ := dst.Get("a"); got != "alpha" {
+			t.Errorf("expected %q, got %q", "alpha", got)
+		}
+	})
+
+	t.Run("WatchFile hot-reloads on change", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "watched.json")
+		seed := NewRegistry[string]()
+		_ = seed.Register("a", "alpha")
+		if err := seed.SnapshotAtomic(path, JSONCodec[string]{}); err != nil {
+			t.Fatalf("SnapshotAtomic failed: %v", err)
+		}
+
+		dst := NewRegistry[string]()
+		stop := dst.WatchFile(path, JSONCodec[string]{}, 10*time.Millisecond)
+		defer stop()
+
+		waitFor(t, func() bool {
+			got, _ := dst.Get("a")
+			return got == "alpha"
+		})
+
+		updated := NewRegistry[string]()
+		_ = updated.Register("b", "beta")
+		if err := updated.SnapshotAtomic(path, JSONCodec[string]{}); err != nil {
+			t.Fatalf("SnapshotAtomic failed: %v", err)
+		}
+
+		waitFor(t, func() bool {
+			got, exists := dst.Get("b")
+			return exists && got == "beta"
+		})
+	})
+}
+
+func TestTypeRegistrySnapshotRestore(t *testing.T) {
+	t.Run("Snapshot/Restore preserves the type tag", func(t *testing.T) {
+		src := NewTypeRegistry[string, ComponentType](Tool, Stage)
+		_ = src.RegisterWithType(Tool, "parser", "parser-impl")
+		_ = src.RegisterWithType(Stage, "parser", "stage-impl")
+
+		var buf bytes.Buffer
+		if err := src.Snapshot(&buf, JSONCodec[string]{}); err != nil {
+			t.Fatalf("Snapshot failed: %v", err)
+		}
+
+		dst := NewTypeRegistry[string, ComponentType](Tool, Stage)
+		if err := dst.Restore(&buf, JSONCodec[string]{}); err != nil {
+			t.Fatalf("Restore failed: %v", err)
+		}
+
+		if got, _ := dst.GetByType(Tool, "parser"); got != "parser-impl" {
+			t.Errorf("expected %q, got %q", "parser-impl", got)
+		}
+		if got, _ := dst.GetByType(Stage, "parser"); got != "stage-impl" {
+			t.Errorf("expected %q, got %q", "stage-impl", got)
+		}
+	})
+
+	t.Run("Restore rejects unknown types", func(t *testing.T) {
+		src := NewTypeRegistry[string, ComponentType](Tool)
+		_ = src.RegisterWithType(Tool, "parser", "parser-impl")
+
+		var buf bytes.Buffer
+		if err := src.Snapshot(&buf, JSONCodec[string]{}); err != nil {
+			t.Fatalf("Snapshot failed: %v", err)
+		}
+
+		dst := NewTypeRegistry[string, ComponentType]() // no valid types
+		if err := dst.Restore(&buf, JSONCodec[string]{}); err == nil {
+			t.Error("expected Restore to reject a snapshot referencing an unknown type")
+		}
+	})
+
+	t.Run("Restore leaves dst untouched when any entry has an unknown type", func(t *testing.T) {
+		src := NewTypeRegistry[string, ComponentType](Tool, Stage)
+		_ = src.RegisterWithType(Tool, "parser", "parser-impl")
+		_ = src.RegisterWithType(Stage, "parser", "stage-impl")
+
+		var buf bytes.Buffer
+		if err := src.Snapshot(&buf, JSONCodec[string]{}); err != nil {
+			t.Fatalf("Snapshot failed: %v", err)
+		}
+
+		dst := NewTypeRegistry[string, ComponentType](Tool) // Stage is not valid here
+		if err := dst.Restore(&buf, JSONCodec[string]{}); err == nil {
+			t.Fatal("expected Restore to reject a snapshot referencing an unknown type")
+		}
+
+		if _, ok := dst.GetByType(Tool, "parser"); ok {
+			t.Error("expected Restore to be a no-op, but the Tool entry was registered")
+		}
+	})
+
+	t.Run("Restore leaves dst untouched when a later entry's key collides with a sub-registry", func(t *testing.T) {
+		entries := []typeSnapshotEntry{
+			{Type: string(Tool), Name: "a"},
+			{Type: string(Tool), Name: "b"},
+		}
+		for i := range entries {
+			data, err := JSONCodec[string]{}.Encode("impl-" + entries[i].Name)
+			if err != nil {
+				t.Fatalf("Encode failed: %v", err)
+			}
+			entries[i].Data = data
+		}
+
+		var buf bytes.Buffer
+		if err := json.NewEncoder(&buf).Encode(entries); err != nil {
+			t.Fatalf("Encode failed: %v", err)
+		}
+
+		dst := NewTypeRegistry[string, ComponentType](Tool)
+		if _, err := dst.NewSubRegistry(string(Tool) + ":b"); err != nil {
+			t.Fatalf("NewSubRegistry failed: %v", err)
+		}
+
+		if err := dst.Restore(&buf, JSONCodec[string]{}); err == nil {
+			t.Fatal("expected Restore to reject a snapshot whose key collides with a sub-registry")
+		}
+
+		if _, ok := dst.GetByType(Tool, "a"); ok {
+			t.Error("expected Restore to be a no-op, but the earlier 'a' entry was registered before the collision on 'b'")
+		}
+	})
+}
+
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if cond() {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatal("condition not met before deadline")
+}
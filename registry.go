@@ -9,6 +9,21 @@ import (
 type Registry[T any] struct {
 	mu    sync.RWMutex
 	items map[string]T
+	subs  map[string]*Registry[T]
+
+	subMu       sync.Mutex
+	subscribers []*subscriber[T]
+	nextSubID   uint64
+
+	handleMu        sync.Mutex
+	handles         map[Handle]*handleEntry[T]
+	handlesByKey    map[string]Handle
+	identityHandles map[uintptr]Handle
+	nextHandle      uint64
+
+	meta       map[string]Metadata
+	tagIndex   map[string]map[string]struct{}
+	labelIndex map[string]map[string]struct{}
 }
 
 // TypeRegistry adds type-safe registration capabilities.
@@ -20,7 +35,14 @@ type TypeRegistry[T any, K comparable] struct {
 // NewRegistry creates a new Registry.
 func NewRegistry[T any]() *Registry[T] {
 	return &Registry[T]{
-		items: make(map[string]T),
+		items:           make(map[string]T),
+		subs:            make(map[string]*Registry[T]),
+		handles:         make(map[Handle]*handleEntry[T]),
+		handlesByKey:    make(map[string]Handle),
+		identityHandles: make(map[uintptr]Handle),
+		meta:            make(map[string]Metadata),
+		tagIndex:        make(map[string]map[string]struct{}),
+		labelIndex:      make(map[string]map[string]struct{}),
 	}
 }
 
@@ -94,16 +116,96 @@ func (r *TypeRegistry[T, K]) ItemsByType(itemType K) map[string]T {
 }
 
 // Register adds an item with the given key.
-// Returns an error if the key already exists.
+// Returns an error if the key already exists, either as an item or as the
+// name of an existing sub-registry (see NewSubRegistry). On success, a
+// Registered event is published to any subscribers (see Subscribe).
 func (r *Registry[T]) Register(key string, item T) error {
 	r.mu.Lock()
-	defer r.mu.Unlock()
 
 	if _, exists := r.items[key]; exists {
+		r.mu.Unlock()
 		return fmt.Errorf("item with key '%s' already exists", key)
 	}
+	if _, exists := r.subs[key]; exists {
+		r.mu.Unlock()
+		return fmt.Errorf("registry: %q is already registered as a sub-registry", key)
+	}
+
+	r.items[key] = item
+	r.mu.Unlock()
+
+	r.emit(Event[T]{Kind: Registered, Key: key, Item: item})
+	return nil
+}
+
+// Set registers an item under key, replacing any existing item rather than
+// erroring like Register does. If key previously carried metadata from
+// RegisterWithMeta, that metadata (and its tag/label index entries) is
+// dropped along with the old item, since it describes the old item, not
+// item. A Replaced event is published if an item already existed under
+// key, otherwise a Registered event is published. It still errors if key
+// is already in use as a sub-registry name.
+func (r *Registry[T]) Set(key string, item T) error {
+	r.mu.Lock()
+
+	if _, clash := r.subs[key]; clash {
+		r.mu.Unlock()
+		return fmt.Errorf("registry: %q is already registered as a sub-registry", key)
+	}
 
+	_, existed := r.items[key]
 	r.items[key] = item
+	r.unindexMeta(key)
+	delete(r.meta, key)
+	r.mu.Unlock()
+
+	kind := Registered
+	if existed {
+		kind = Replaced
+	}
+	r.emit(Event[T]{Kind: kind, Key: key, Item: item})
+	return nil
+}
+
+// setManyEntry is one key/item pair passed to setMany.
+type setManyEntry[T any] struct {
+	key  string
+	item T
+}
+
+// setMany applies entries as if by calling Set for each, but atomically:
+// every key is checked against the sub-registry namespace before any item
+// is written, so if one entry clashes, none of the entries are applied.
+// Callers needing an all-or-nothing batch restore (see TypeRegistry.Restore)
+// should use this instead of calling Set in a loop. Events are published,
+// one per entry in order, after the whole batch has committed.
+func (r *Registry[T]) setMany(entries []setManyEntry[T]) error {
+	r.mu.Lock()
+
+	for _, e := range entries {
+		if _, clash := r.subs[e.key]; clash {
+			r.mu.Unlock()
+			return fmt.Errorf("registry: %q is already registered as a sub-registry", e.key)
+		}
+	}
+
+	kinds := make([]EventKind, len(entries))
+	for i, e := range entries {
+		_, existed := r.items[e.key]
+		r.items[e.key] = e.item
+		r.unindexMeta(e.key)
+		delete(r.meta, e.key)
+		if existed {
+			kinds[i] = Replaced
+		} else {
+			kinds[i] = Registered
+		}
+	}
+	r.mu.Unlock()
+
+	for i, e := range entries {
+		r.emit(Event[T]{Kind: kinds[i], Key: e.key, Item: e.item})
+	}
 	return nil
 }
 
@@ -118,16 +220,25 @@ func (r *Registry[T]) Get(key string) (T, bool) {
 }
 
 // Delete removes an item from the registry.
-// Returns true if the item was found and deleted, false otherwise.
+// Returns true if the item was found and deleted, false otherwise. On
+// success, a Deleted event is published to any subscribers (see Subscribe).
 func (r *Registry[T]) Delete(key string) bool {
 	r.mu.Lock()
-	defer r.mu.Unlock()
 
-	if _, exists := r.items[key]; !exists {
+	item, exists := r.items[key]
+	if !exists {
+		r.mu.Unlock()
 		return false
 	}
 
 	delete(r.items, key)
+	r.unindexMeta(key)
+	delete(r.meta, key)
+	r.mu.Unlock()
+
+	r.markHandleDeleted(key)
+
+	r.emit(Event[T]{Kind: Deleted, Key: key, Item: item})
 	return true
 }
 
@@ -0,0 +1,136 @@
+package registry
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// NewSubRegistry creates (or returns, if it already exists) a nested
+// Registry reachable at the given dotted path, e.g. "http.server.requests"
+// creates "http", then "server", then "requests" underneath it as needed.
+// It returns an error if any path segment collides with an existing leaf
+// item registered at that level.
+func (r *Registry[T]) NewSubRegistry(name string) (*Registry[T], error) {
+	if name == "" {
+		return nil, fmt.Errorf("registry: sub-registry name must not be empty")
+	}
+	return r.subRegistry(strings.Split(name, "."), true)
+}
+
+// GetRegistry returns the sub-registry reachable at the given dotted path,
+// or nil if no such sub-registry exists.
+func (r *Registry[T]) GetRegistry(path string) *Registry[T] {
+	sub, _ := r.subRegistry(strings.Split(path, "."), false)
+	return sub
+}
+
+// DeleteRegistry removes the sub-registry reachable at the given dotted
+// path, along with everything nested beneath it. It returns true if a
+// sub-registry was found and removed.
+func (r *Registry[T]) DeleteRegistry(name string) bool {
+	segments := strings.Split(name, ".")
+	parent, _ := r.subRegistry(segments[:len(segments)-1], false)
+	if parent == nil {
+		return false
+	}
+
+	last := segments[len(segments)-1]
+	parent.mu.Lock()
+	defer parent.mu.Unlock()
+
+	if _, exists := parent.subs[last]; !exists {
+		return false
+	}
+	delete(parent.subs, last)
+	return true
+}
+
+// Visit walks the registry tree depth-first in a stable order (items before
+// sub-registries, both sorted by name at each level), invoking fn with the
+// dotted full name of every item. fn is never called while a Registry lock
+// is held, so it is safe for fn to call back into the tree.
+func (r *Registry[T]) Visit(fn func(fullName string, item T)) {
+	r.visit("", fn)
+}
+
+func (r *Registry[T]) visit(prefix string, fn func(fullName string, item T)) {
+	items := r.Items()
+	keys := make([]string, 0, len(items))
+	for k := range items {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		fn(joinPath(prefix, k), items[k])
+	}
+
+	r.mu.RLock()
+	subNames := make([]string, 0, len(r.subs))
+	subs := make(map[string]*Registry[T], len(r.subs))
+	for name, sub := range r.subs {
+		subNames = append(subNames, name)
+		subs[name] = sub
+	}
+	r.mu.RUnlock()
+
+	sort.Strings(subNames)
+	for _, name := range subNames {
+		subs[name].visit(joinPath(prefix, name), fn)
+	}
+}
+
+// subRegistry walks segments from r, optionally creating missing
+// sub-registries along the way. With create set to false it performs a
+// read-only lookup and returns (nil, nil) if the path doesn't exist.
+func (r *Registry[T]) subRegistry(segments []string, create bool) (*Registry[T], error) {
+	cur := r
+	for _, seg := range segments {
+		if seg == "" {
+			return nil, fmt.Errorf("registry: empty path segment in %q", strings.Join(segments, "."))
+		}
+
+		cur.mu.Lock()
+		next, exists := cur.subs[seg]
+		if !exists {
+			if !create {
+				cur.mu.Unlock()
+				return nil, nil
+			}
+			if _, clash := cur.items[seg]; clash {
+				cur.mu.Unlock()
+				return nil, fmt.Errorf("registry: %q is already registered as an item", seg)
+			}
+			next = NewRegistry[T]()
+			cur.subs[seg] = next
+		}
+		cur.mu.Unlock()
+		cur = next
+	}
+	return cur, nil
+}
+
+func joinPath(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + "." + name
+}
+
+// NewSubRegistry creates (or returns) a nested Registry reachable at the
+// given dotted path under the type registry's underlying registry.
+func (r *TypeRegistry[T, K]) NewSubRegistry(name string) (*Registry[T], error) {
+	return r.registry.NewSubRegistry(name)
+}
+
+// GetRegistry returns the sub-registry reachable at the given dotted path,
+// or nil if no such sub-registry exists.
+func (r *TypeRegistry[T, K]) GetRegistry(path string) *Registry[T] {
+	return r.registry.GetRegistry(path)
+}
+
+// Visit walks the underlying registry tree depth-first in a stable order,
+// invoking fn with the dotted full name of every item.
+func (r *TypeRegistry[T, K]) Visit(fn func(fullName string, item T)) {
+	r.registry.Visit(fn)
+}
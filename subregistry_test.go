@@ -0,0 +1,93 @@
+package registry
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRegistryHierarchy(t *testing.T) {
+	t.Run("dotted path creates intermediate sub-registries", func(t *testing.T) {
+		root := NewRegistry[int]()
+
+		sub, err := root.NewSubRegistry("http.server.requests")
+		if err != nil {
+			t.Fatalf("NewSubRegistry failed: %v", err)
+		}
+
+		if err := sub.Register("count", 1); err != nil {
+			t.Fatalf("Register failed: %v", err)
+		}
+
+		if got := root.GetRegistry("http.server.requests"); got != sub {
+			t.Error("GetRegistry did not return the same sub-registry")
+		}
+		if got := root.GetRegistry("http.server"); got == nil {
+			t.Error("expected intermediate sub-registry \"http.server\" to exist")
+		}
+		if got := root.GetRegistry("missing.path"); got != nil {
+			t.Error("expected nil for a path that doesn't exist")
+		}
+
+		// Re-creating the same path returns the existing sub-registry.
+		again, err := root.NewSubRegistry("http.server.requests")
+		if err != nil {
+			t.Fatalf("NewSubRegistry failed on re-creation: %v", err)
+		}
+		if again != sub {
+			t.Error("expected NewSubRegistry to return the existing sub-registry")
+		}
+	})
+
+	t.Run("leaf key collisions error", func(t *testing.T) {
+		root := NewRegistry[int]()
+
+		if err := root.Register("http", 1); err != nil {
+			t.Fatalf("Register failed: %v", err)
+		}
+		if _, err := root.NewSubRegistry("http"); err == nil {
+			t.Error("expected error creating a sub-registry over an existing item key")
+		}
+
+		if _, err := root.NewSubRegistry("server"); err != nil {
+			t.Fatalf("NewSubRegistry failed: %v", err)
+		}
+		if err := root.Register("server", 2); err == nil {
+			t.Error("expected error registering an item over an existing sub-registry name")
+		}
+	})
+
+	t.Run("Visit walks depth-first in stable order", func(t *testing.T) {
+		root := NewRegistry[int]()
+		_ = root.Register("b", 2)
+		_ = root.Register("a", 1)
+
+		sub, _ := root.NewSubRegistry("z")
+		_ = sub.Register("y", 3)
+
+		var visited []string
+		root.Visit(func(fullName string, item int) {
+			visited = append(visited, fullName)
+		})
+
+		want := []string{"a", "b", "z.y"}
+		if strings.Join(visited, ",") != strings.Join(want, ",") {
+			t.Errorf("Visit order = %v, want %v", visited, want)
+		}
+	})
+
+	t.Run("DeleteRegistry removes the sub-tree", func(t *testing.T) {
+		root := NewRegistry[int]()
+		sub, _ := root.NewSubRegistry("http.server")
+		_ = sub.Register("count", 1)
+
+		if !root.DeleteRegistry("http.server") {
+			t.Error("expected DeleteRegistry to succeed")
+		}
+		if got := root.GetRegistry("http.server"); got != nil {
+			t.Error("expected sub-registry to be gone after DeleteRegistry")
+		}
+		if root.DeleteRegistry("http.server") {
+			t.Error("expected DeleteRegistry to fail on an already-deleted path")
+		}
+	})
+}